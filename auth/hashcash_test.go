@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func solve(t *testing.T, c *HashcashChallenger, challenge string) string {
+	t.Helper()
+	for nonce := 0; nonce < 1_000_000; nonce++ {
+		header := challenge + ":" + strconv.Itoa(nonce)
+		if err := c.Verify(header); err == nil {
+			return header
+		}
+	}
+	t.Fatalf("failed to find a valid nonce for challenge %q", challenge)
+	return ""
+}
+
+func TestVerifyAcceptsValidProofOfWork(t *testing.T) {
+	c := NewHashcashChallenger(8, 100)
+
+	challenge, err := c.Issue()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// solve() itself calls Verify to find a valid nonce, consuming the
+	// challenge, so there's nothing left to assert beyond it succeeding.
+	solve(t, c, challenge)
+}
+
+func TestVerifyRejectsMalformedHeader(t *testing.T) {
+	c := NewHashcashChallenger(8, 100)
+
+	if err := c.Verify("not-a-valid-header"); err != ErrProofOfWorkInvalid {
+		t.Fatalf("expected ErrProofOfWorkInvalid, got %v", err)
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	c := NewHashcashChallenger(1, 100)
+
+	challenge, err := c.Issue()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	header := solve(t, c, challenge)
+
+	if err := c.Verify(header); err != ErrProofOfWorkInvalid {
+		t.Fatalf("expected replay to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyRejectsConcurrentReplay(t *testing.T) {
+	c := NewHashcashChallenger(1, 100)
+
+	challenge, err := c.Issue()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	nonce := findNonce(t, c.Difficulty, challenge)
+	header := challenge + ":" + nonce
+
+	const n = 20
+	var wg sync.WaitGroup
+	results := make([]error, n)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.Verify(header)
+		}(i)
+	}
+	wg.Wait()
+
+	accepted := 0
+	for _, err := range results {
+		if err == nil {
+			accepted++
+		}
+	}
+	if accepted != 1 {
+		t.Fatalf("expected exactly one concurrent Verify of the same header to succeed, got %d", accepted)
+	}
+}
+
+// findNonce solves a challenge without consuming it, so the caller can
+// replay the resulting header themselves.
+func findNonce(t *testing.T, difficulty int, challenge string) string {
+	t.Helper()
+	scratch := NewHashcashChallenger(difficulty, 1)
+	for nonce := 0; nonce < 1_000_000; nonce++ {
+		candidate := strconv.Itoa(nonce)
+		if err := scratch.Verify(challenge + ":" + candidate); err == nil {
+			return candidate
+		}
+	}
+	t.Fatalf("failed to find a valid nonce for challenge %q", challenge)
+	return ""
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIssueAndParseToken(t *testing.T) {
+	secret := []byte("test-secret")
+
+	token, err := IssueToken(secret, "alice", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := ParseToken(secret, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.UserID != "alice" {
+		t.Fatalf("expected userID alice, got %q", claims.UserID)
+	}
+}
+
+func TestParseTokenRejectsTamperedSignature(t *testing.T) {
+	secret := []byte("test-secret")
+
+	token, err := IssueToken(secret, "alice", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = ParseToken(secret, token+"tampered")
+	if err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestParseTokenRejectsExpired(t *testing.T) {
+	secret := []byte("test-secret")
+
+	token, err := IssueToken(secret, "alice", -time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = ParseToken(secret, token)
+	if err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
+
+func TestParseTokenRejectsWrongSecret(t *testing.T) {
+	token, err := IssueToken([]byte("secret-a"), "alice", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = ParseToken([]byte("secret-b"), token)
+	if err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken, got %v", err)
+	}
+}
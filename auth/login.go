@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Credentials is the body expected by LoginHandler and SignupHandler.
+type Credentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginHandler authenticates credentials against users and, on success,
+// writes a JSON body of the form {"token": "..."} containing a JWT valid
+// for ttl.
+func LoginHandler(secret []byte, users UserStore, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		var creds Credentials
+		if err := json.Unmarshal(bodyBytes, &creds); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		userID, err := users.Authenticate(creds.Username, creds.Password)
+		if errors.Is(err, ErrInvalidCredentials) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		writeToken(w, secret, userID, ttl)
+	}
+}
+
+func writeToken(w http.ResponseWriter, secret []byte, userID string, ttl time.Duration) {
+	token, err := IssueToken(secret, userID, ttl)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Header().Set("content-type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
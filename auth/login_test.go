@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLoginHandlerRoundTrip(t *testing.T) {
+	secret := []byte("test-secret")
+	users := NewMemoryUsers()
+	if err := users.Register("alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, _ := json.Marshal(Credentials{Username: "alice", Password: "hunter2"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+	LoginHandler(secret, users, time.Hour).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims, err := ParseToken(secret, resp["token"])
+	if err != nil {
+		t.Fatalf("expected a valid token, got error: %v", err)
+	}
+	if claims.UserID != "alice" {
+		t.Fatalf("expected userID alice, got %q", claims.UserID)
+	}
+}
+
+func TestLoginHandlerRejectsWrongPassword(t *testing.T) {
+	secret := []byte("test-secret")
+	users := NewMemoryUsers()
+	if err := users.Register("alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, _ := json.Marshal(Credentials{Username: "alice", Password: "wrong"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+	LoginHandler(secret, users, time.Hour).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rec.Code)
+	}
+}
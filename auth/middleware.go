@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/alesbrelih/rest-go-no-framework/router"
+)
+
+type userIDKey struct{}
+
+// UserID returns the authenticated user's id stored in the request context
+// by Middleware, or "" if the request never went through it.
+func UserID(r *http.Request) string {
+	id, _ := r.Context().Value(userIDKey{}).(string)
+	return id
+}
+
+// Middleware validates the "Authorization: Bearer <token>" header and
+// injects the resulting user id into the request context, retrievable via
+// UserID. Requests without a valid token are rejected with 401.
+func Middleware(secret []byte) router.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token := strings.TrimPrefix(r.Header.Get("authorization"), "Bearer ")
+			if token == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte("missing bearer token"))
+				return
+			}
+
+			claims, err := ParseToken(secret, token)
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(err.Error()))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userIDKey{}, claims.UserID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
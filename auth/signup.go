@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// SignupHandler registers a new user and issues a JWT for them. It has no
+// prior authentication to rely on, so it is meant to sit behind
+// HashcashChallenger.RequireProofOfWork.
+func SignupHandler(secret []byte, users *MemoryUsers, ttl time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bodyBytes, err := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		var creds Credentials
+		if err := json.Unmarshal(bodyBytes, &creds); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if creds.Username == "" || creds.Password == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("username and password are required"))
+			return
+		}
+
+		err = users.Register(creds.Username, creds.Password)
+		if errors.Is(err, ErrUsernameTaken) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		writeToken(w, secret, creds.Username, ttl)
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSignupHandlerIssuesToken(t *testing.T) {
+	secret := []byte("test-secret")
+	users := NewMemoryUsers()
+
+	body, _ := json.Marshal(Credentials{Username: "alice", Password: "hunter2"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(body))
+	SignupHandler(secret, users, time.Hour).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var resp map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ParseToken(secret, resp["token"]); err != nil {
+		t.Fatalf("expected a valid token, got error: %v", err)
+	}
+}
+
+func TestSignupHandlerRejectsDuplicateUsername(t *testing.T) {
+	secret := []byte("test-secret")
+	users := NewMemoryUsers()
+	if err := users.Register("alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	body, _ := json.Marshal(Credentials{Username: "alice", Password: "different"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(body))
+	SignupHandler(secret, users, time.Hour).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", rec.Code)
+	}
+}
+
+func TestSignupHandlerRejectsMissingFields(t *testing.T) {
+	secret := []byte("test-secret")
+	users := NewMemoryUsers()
+
+	body, _ := json.Marshal(Credentials{Username: "alice"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup", bytes.NewReader(body))
+	SignupHandler(secret, users, time.Hour).ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidCredentials is returned by UserStore.Authenticate for an
+// unknown username or wrong password.
+var ErrInvalidCredentials = errors.New("auth: invalid credentials")
+
+// ErrUsernameTaken is returned by MemoryUsers.Register for a username that
+// already exists.
+var ErrUsernameTaken = errors.New("auth: username already taken")
+
+// UserStore authenticates credentials and returns a stable user id used to
+// scope a user's todos.
+type UserStore interface {
+	Authenticate(username, password string) (userID string, err error)
+}
+
+// MemoryUsers is a mutex-guarded, in-memory UserStore. Passwords are hashed
+// with bcrypt before being stored; it exists for local/demo wiring only -
+// swap in a real user store before deploying this anywhere.
+type MemoryUsers struct {
+	mu    sync.Mutex
+	users map[string][]byte // username -> bcrypt hash
+}
+
+// NewMemoryUsers returns an empty MemoryUsers.
+func NewMemoryUsers() *MemoryUsers {
+	return &MemoryUsers{users: map[string][]byte{}}
+}
+
+func (m *MemoryUsers) Authenticate(username, password string) (string, error) {
+	m.mu.Lock()
+	hash, ok := m.users[username]
+	m.mu.Unlock()
+
+	if !ok || bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil {
+		return "", ErrInvalidCredentials
+	}
+	return username, nil
+}
+
+// Register adds a new user, failing if username is already taken.
+func (m *MemoryUsers) Register(username, password string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.users[username]; exists {
+		return ErrUsernameTaken
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	m.users[username] = hash
+	return nil
+}
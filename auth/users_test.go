@@ -0,0 +1,49 @@
+package auth
+
+import "testing"
+
+func TestMemoryUsersRegisterAndAuthenticate(t *testing.T) {
+	users := NewMemoryUsers()
+
+	if err := users.Register("alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	userID, err := users.Authenticate("alice", "hunter2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if userID != "alice" {
+		t.Fatalf("expected userID alice, got %q", userID)
+	}
+}
+
+func TestMemoryUsersRegisterRejectsDuplicate(t *testing.T) {
+	users := NewMemoryUsers()
+
+	if err := users.Register("alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := users.Register("alice", "different"); err != ErrUsernameTaken {
+		t.Fatalf("expected ErrUsernameTaken, got %v", err)
+	}
+}
+
+func TestMemoryUsersAuthenticateRejectsWrongPassword(t *testing.T) {
+	users := NewMemoryUsers()
+	if err := users.Register("alice", "hunter2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := users.Authenticate("alice", "wrong"); err != ErrInvalidCredentials {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
+
+func TestMemoryUsersAuthenticateRejectsUnknownUser(t *testing.T) {
+	users := NewMemoryUsers()
+
+	if _, err := users.Authenticate("ghost", "anything"); err != ErrInvalidCredentials {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
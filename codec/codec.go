@@ -0,0 +1,70 @@
+// Package codec negotiates the wire format used to encode and decode HTTP
+// request/response bodies, so handlers don't have to hard-code JSON.
+package codec
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Codec marshals and unmarshals values for a specific wire format.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	ContentType() string
+}
+
+var registry = map[string]Codec{}
+
+// Default is used whenever negotiation finds no match. It is JSON, matching
+// the API's original behaviour.
+var Default Codec
+
+// Register adds c to the registry under its content type, overwriting any
+// codec previously registered for that type.
+func Register(c Codec) {
+	registry[c.ContentType()] = c
+}
+
+// ForContentType returns the codec registered for contentType (as found in
+// a request's Content-Type header), or Default if none matches.
+func ForContentType(contentType string) Codec {
+	if c, ok := registry[stripParams(contentType)]; ok {
+		return c
+	}
+	return Default
+}
+
+// Negotiate picks a codec based on a request's Accept header, returning the
+// first registered match or Default if the header is empty or matches
+// nothing we support.
+func Negotiate(accept string) Codec {
+	for _, part := range strings.Split(accept, ",") {
+		if c, ok := registry[stripParams(strings.TrimSpace(part))]; ok {
+			return c
+		}
+	}
+	return Default
+}
+
+// WriteResponse negotiates a codec from the request's Accept header,
+// marshals v, sets the response Content-Type and writes the result.
+func WriteResponse(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	c := Negotiate(r.Header.Get("accept"))
+
+	data, err := c.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("content-type", c.ContentType())
+	_, err = w.Write(data)
+	return err
+}
+
+func stripParams(contentType string) string {
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return strings.TrimSpace(contentType)
+}
@@ -0,0 +1,24 @@
+package codec
+
+import "testing"
+
+func TestNegotiatePicksMatchingCodec(t *testing.T) {
+	c := Negotiate("application/msgpack")
+	if c.ContentType() != "application/msgpack" {
+		t.Fatalf("expected msgpack codec, got %q", c.ContentType())
+	}
+}
+
+func TestNegotiateFallsBackToDefault(t *testing.T) {
+	c := Negotiate("text/html")
+	if c != Default {
+		t.Fatalf("expected Default codec for unsupported Accept header")
+	}
+}
+
+func TestForContentTypeIgnoresParams(t *testing.T) {
+	c := ForContentType("application/protobuf; charset=utf-8")
+	if c.ContentType() != "application/protobuf" {
+		t.Fatalf("expected protobuf codec, got %q", c.ContentType())
+	}
+}
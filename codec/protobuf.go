@@ -0,0 +1,41 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// protobufCodec marshals values that are already proto.Message, or that
+// expose a ToProto/FromProto conversion (see internals/todo for the Todo
+// adapter methods), since plain structs like Todo aren't proto messages
+// themselves.
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(v interface{}) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		conv, ok := v.(interface{ ToProto() proto.Message })
+		if !ok {
+			return nil, fmt.Errorf("codec: %T does not support protobuf", v)
+		}
+		msg = conv.ToProto()
+	}
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v interface{}) error {
+	if msg, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, msg)
+	}
+	if conv, ok := v.(interface{ FromProto([]byte) error }); ok {
+		return conv.FromProto(data)
+	}
+	return fmt.Errorf("codec: %T does not support protobuf", v)
+}
+
+func (protobufCodec) ContentType() string { return "application/protobuf" }
+
+func init() {
+	Register(protobufCodec{})
+}
@@ -0,0 +1,136 @@
+// Package bolt provides a todo.Store backed by a BoltDB file, for
+// deployments that want single-file persistence without a database server.
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+// Store is a todo.Store backed by a BoltDB database. Each user gets their
+// own top-level bucket, keyed by userID, so todos never leak across users.
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open opens (creating if necessary) the BoltDB file at path and returns a
+// ready-to-use Store.
+func Open(path string) (*Store, error) {
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) Get(userID string, id int64) (todo.Todo, error) {
+	var t todo.Todo
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(userID))
+		if b == nil {
+			return todo.ErrNotFound
+		}
+		v := b.Get(idKey(id))
+		if v == nil {
+			return todo.ErrNotFound
+		}
+		return json.Unmarshal(v, &t)
+	})
+	return t, err
+}
+
+func (s *Store) List(userID string, filter todo.Filter) ([]todo.Todo, error) {
+	var todos []todo.Todo
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(userID))
+		if b == nil {
+			return nil
+		}
+
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var t todo.Todo
+			if err := json.Unmarshal(v, &t); err != nil {
+				return err
+			}
+			if filter.Done != nil && t.Done != *filter.Done {
+				continue
+			}
+			todos = append(todos, t)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(todos) {
+			return []todo.Todo{}, nil
+		}
+		todos = todos[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(todos) {
+		todos = todos[:filter.Limit]
+	}
+
+	return todos, nil
+}
+
+func (s *Store) Put(userID string, t todo.Todo) (todo.Todo, error) {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(userID))
+		if err != nil {
+			return err
+		}
+
+		if t.Id == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			t.Id = int64(id)
+		}
+
+		v, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		return b.Put(idKey(t.Id), v)
+	})
+	return t, err
+}
+
+func (s *Store) Delete(userID string, id int64) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket([]byte(userID))
+		if b == nil || b.Get(idKey(id)) == nil {
+			return todo.ErrNotFound
+		}
+		return b.Delete(idKey(id))
+	})
+}
+
+// Watch is not supported by the BoltDB store: a file changed purely via
+// bbolt transactions has no in-process fan-out point to observe.
+func (s *Store) Watch(ctx context.Context, userID string) (<-chan todo.Event, error) {
+	return nil, errors.New("bolt: watch not implemented")
+}
+
+func idKey(id int64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(id))
+	return buf
+}
@@ -0,0 +1,151 @@
+// Package memory provides an in-memory todo.Store backed by a map. It is
+// the default store used when no persistent backend is configured.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+type key struct {
+	userID string
+	id     int64
+}
+
+type subscription struct {
+	userID string
+	ch     chan todo.Event
+}
+
+// Store is an in-memory implementation of todo.Store, scoped per user via a
+// compound (userID, id) key.
+type Store struct {
+	mu     sync.Mutex // good practice to keep mutex near the data its trying to protect
+	todos  map[key]todo.Todo
+	nextID map[string]int64
+	subs   []subscription
+}
+
+// New returns a Store seeded with a couple of example todos for the "demo"
+// user.
+func New() *Store {
+	return &Store{
+		todos: map[key]todo.Todo{
+			{userID: "demo", id: 1}: {Id: 1, Title: "Do dishes", Done: false},
+			{userID: "demo", id: 2}: {Id: 2, Title: "Sweep", Done: true},
+		},
+		nextID: map[string]int64{"demo": 2},
+	}
+}
+
+func (s *Store) Get(userID string, id int64) (todo.Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, found := s.todos[key{userID, id}]
+	if !found {
+		return todo.Todo{}, todo.ErrNotFound
+	}
+	return t, nil
+}
+
+func (s *Store) List(userID string, filter todo.Filter) ([]todo.Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	todos := make([]todo.Todo, 0, len(s.todos))
+	for k, t := range s.todos {
+		if k.userID != userID {
+			continue
+		}
+		if filter.Done != nil && t.Done != *filter.Done {
+			continue
+		}
+		todos = append(todos, t)
+	}
+
+	sort.Slice(todos, func(i, j int) bool { return todos[i].Id < todos[j].Id })
+
+	if filter.Offset > 0 {
+		if filter.Offset >= len(todos) {
+			return []todo.Todo{}, nil
+		}
+		todos = todos[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(todos) {
+		todos = todos[:filter.Limit]
+	}
+
+	return todos, nil
+}
+
+func (s *Store) Put(userID string, t todo.Todo) (todo.Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.Id == 0 {
+		s.nextID[userID]++
+		t.Id = s.nextID[userID]
+	}
+	s.todos[key{userID, t.Id}] = t
+	s.publish(userID, todo.Event{Type: todo.EventPut, Todo: t})
+	return t, nil
+}
+
+func (s *Store) Delete(userID string, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := key{userID, id}
+	t, found := s.todos[k]
+	if !found {
+		return todo.ErrNotFound
+	}
+	delete(s.todos, k)
+	s.publish(userID, todo.Event{Type: todo.EventDelete, Todo: t})
+	return nil
+}
+
+// Watch returns a channel of userID's todo events. The channel is closed
+// once ctx is cancelled.
+func (s *Store) Watch(ctx context.Context, userID string) (<-chan todo.Event, error) {
+	ch := make(chan todo.Event, 16)
+	sub := subscription{userID: userID, ch: ch}
+
+	s.mu.Lock()
+	s.subs = append(s.subs, sub)
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		for i, su := range s.subs {
+			if su.ch == ch {
+				s.subs = append(s.subs[:i], s.subs[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+// publish must be called with s.mu held.
+func (s *Store) publish(userID string, evt todo.Event) {
+	for _, sub := range s.subs {
+		if sub.userID != userID {
+			continue
+		}
+		select {
+		case sub.ch <- evt:
+		default: // drop the event rather than block the writer on a slow watcher
+		}
+	}
+}
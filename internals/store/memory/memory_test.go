@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+func TestPutAssignsID(t *testing.T) {
+	s := New()
+
+	stored, err := s.Put("demo", todo.Todo{Title: "Read a book"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored.Id == 0 {
+		t.Fatalf("expected Put to assign a non-zero id")
+	}
+
+	got, err := s.Get("demo", stored.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != "Read a book" {
+		t.Fatalf("expected stored title to round-trip, got %q", got.Title)
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	s := New()
+
+	_, err := s.Get("demo", 999)
+	if err != todo.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	s := New()
+
+	err := s.Delete("demo", 999)
+	if err != todo.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreIsScopedPerUser(t *testing.T) {
+	s := New()
+
+	stored, err := s.Put("alice", todo.Todo{Title: "Alice's todo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// alice is a brand new user, so her first todo also gets id 1 - the
+	// same id demo's seed data uses. Scoping must still keep them apart.
+	got, err := s.Get("demo", stored.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title == "Alice's todo" {
+		t.Fatalf("expected demo's todo with id %d to not be alice's", stored.Id)
+	}
+
+	todos, err := s.List("demo", todo.Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(todos) != 2 {
+		t.Fatalf("expected alice's todo to not leak into demo's list, got %d todos", len(todos))
+	}
+}
+
+func TestListFilterAndPagination(t *testing.T) {
+	s := New()
+	done := true
+	got, err := s.List("demo", todo.Filter{Done: &done})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Id != 2 {
+		t.Fatalf("expected only the done todo, got %v", got)
+	}
+
+	got, err = s.List("demo", todo.Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Id != 1 {
+		t.Fatalf("expected limit to return the first todo, got %v", got)
+	}
+
+	got, err = s.List("demo", todo.Filter{Offset: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Id != 2 {
+		t.Fatalf("expected offset to skip the first todo, got %v", got)
+	}
+}
+
+func TestWatchReceivesEvents(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := s.Watch(ctx, "demo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := s.Put("demo", todo.Todo{Title: "Walk the dog"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	evt := <-ch
+	if evt.Type != todo.EventPut || evt.Todo.Title != "Walk the dog" {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+}
+
+func TestWatchDoesNotReceiveOtherUsersEvents(t *testing.T) {
+	s := New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := s.Watch(ctx, "demo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := s.Put("alice", todo.Todo{Title: "Alice's todo"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case evt := <-ch:
+		t.Fatalf("expected no event for demo's watcher, got %+v", evt)
+	default:
+	}
+}
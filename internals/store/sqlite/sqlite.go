@@ -0,0 +1,145 @@
+// Package sqlite provides a todo.Store backed by a SQLite database.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS todos (
+	id      INTEGER NOT NULL,
+	user_id TEXT    NOT NULL,
+	title   TEXT    NOT NULL,
+	done    BOOLEAN NOT NULL DEFAULT 0,
+	PRIMARY KEY (user_id, id)
+);`
+
+// Store is a todo.Store backed by a SQLite database, scoped per user via
+// the user_id column.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (creating if necessary) the SQLite database at path and
+// returns a ready-to-use Store.
+func Open(path string) (*Store, error) {
+	// _txlock=immediate makes every transaction take the write lock up
+	// front instead of deferring it, so Put's id lookup and insert can't
+	// interleave with a concurrent Put's; busy_timeout makes the loser
+	// wait for the lock instead of failing outright.
+	db, err := sql.Open("sqlite3", path+"?_txlock=immediate&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func (s *Store) Get(userID string, id int64) (todo.Todo, error) {
+	var t todo.Todo
+	row := s.db.QueryRow(`SELECT id, title, done FROM todos WHERE user_id = ? AND id = ?`, userID, id)
+	err := row.Scan(&t.Id, &t.Title, &t.Done)
+	if errors.Is(err, sql.ErrNoRows) {
+		return todo.Todo{}, todo.ErrNotFound
+	}
+	return t, err
+}
+
+func (s *Store) List(userID string, filter todo.Filter) ([]todo.Todo, error) {
+	query := `SELECT id, title, done FROM todos WHERE user_id = ?`
+	args := []interface{}{userID}
+
+	if filter.Done != nil {
+		query += ` AND done = ?`
+		args = append(args, *filter.Done)
+	}
+	query += ` ORDER BY id`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	} else if filter.Offset > 0 {
+		// SQLite requires a LIMIT to use OFFSET; -1 means "no limit".
+		query += ` LIMIT -1`
+	}
+	if filter.Offset > 0 {
+		query += ` OFFSET ?`
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	todos := []todo.Todo{}
+	for rows.Next() {
+		var t todo.Todo
+		if err := rows.Scan(&t.Id, &t.Title, &t.Done); err != nil {
+			return nil, err
+		}
+		todos = append(todos, t)
+	}
+	return todos, rows.Err()
+}
+
+func (s *Store) Put(userID string, t todo.Todo) (todo.Todo, error) {
+	// The id lookup and the insert must be atomic, otherwise two
+	// concurrent Put calls for the same user can read the same MAX(id)
+	// and the second INSERT's ON CONFLICT DO UPDATE silently overwrites
+	// the first todo instead of creating a second row.
+	tx, err := s.db.Begin()
+	if err != nil {
+		return todo.Todo{}, err
+	}
+	defer tx.Rollback()
+
+	if t.Id == 0 {
+		row := tx.QueryRow(`SELECT COALESCE(MAX(id), 0) + 1 FROM todos WHERE user_id = ?`, userID)
+		if err := row.Scan(&t.Id); err != nil {
+			return todo.Todo{}, err
+		}
+	}
+
+	if _, err := tx.Exec(`INSERT INTO todos (user_id, id, title, done) VALUES (?, ?, ?, ?)
+		ON CONFLICT(user_id, id) DO UPDATE SET title = excluded.title, done = excluded.done`,
+		userID, t.Id, t.Title, t.Done); err != nil {
+		return todo.Todo{}, err
+	}
+
+	return t, tx.Commit()
+}
+
+func (s *Store) Delete(userID string, id int64) error {
+	res, err := s.db.Exec(`DELETE FROM todos WHERE user_id = ? AND id = ?`, userID, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return todo.ErrNotFound
+	}
+	return nil
+}
+
+// Watch is not supported by the SQLite store; a polling or trigger-based
+// implementation would be needed to emit change events.
+func (s *Store) Watch(ctx context.Context, userID string) (<-chan todo.Event, error) {
+	return nil, errors.New("sqlite: watch not implemented")
+}
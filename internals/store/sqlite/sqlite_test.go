@@ -0,0 +1,177 @@
+package sqlite
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+func open(t *testing.T) *Store {
+	t.Helper()
+	s, err := Open(filepath.Join(t.TempDir(), "todos.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func TestPutAssignsID(t *testing.T) {
+	s := open(t)
+
+	stored, err := s.Put("demo", todo.Todo{Title: "Read a book"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored.Id == 0 {
+		t.Fatalf("expected Put to assign a non-zero id")
+	}
+
+	got, err := s.Get("demo", stored.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title != "Read a book" {
+		t.Fatalf("expected stored title to round-trip, got %q", got.Title)
+	}
+}
+
+func TestGetNotFound(t *testing.T) {
+	s := open(t)
+
+	_, err := s.Get("demo", 999)
+	if err != todo.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	s := open(t)
+
+	err := s.Delete("demo", 999)
+	if err != todo.ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	s := open(t)
+
+	stored, err := s.Put("demo", todo.Todo{Title: "Read a book"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := s.Delete("demo", stored.Id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Get("demo", stored.Id); err != todo.ErrNotFound {
+		t.Fatalf("expected deleted todo to be gone, got %v", err)
+	}
+}
+
+func TestStoreIsScopedPerUser(t *testing.T) {
+	s := open(t)
+
+	if _, err := s.Put("demo", todo.Todo{Title: "Demo's todo"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored, err := s.Put("alice", todo.Todo{Title: "Alice's todo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// alice is a brand new user, so her first todo also gets id 1 - the
+	// same id demo's first todo uses. Scoping must still keep them apart.
+	got, err := s.Get("demo", stored.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Title == "Alice's todo" {
+		t.Fatalf("expected demo's todo with id %d to not be alice's", stored.Id)
+	}
+
+	todos, err := s.List("demo", todo.Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(todos) != 1 {
+		t.Fatalf("expected alice's todo to not leak into demo's list, got %d todos", len(todos))
+	}
+}
+
+func TestListFilterAndPagination(t *testing.T) {
+	s := open(t)
+
+	if _, err := s.Put("demo", todo.Todo{Title: "Do dishes", Done: false}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Put("demo", todo.Todo{Title: "Sweep", Done: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := true
+	got, err := s.List("demo", todo.Filter{Done: &done})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Id != 2 {
+		t.Fatalf("expected only the done todo, got %v", got)
+	}
+
+	got, err = s.List("demo", todo.Filter{Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Id != 1 {
+		t.Fatalf("expected limit to return the first todo, got %v", got)
+	}
+
+	got, err = s.List("demo", todo.Filter{Offset: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Id != 2 {
+		t.Fatalf("expected offset to skip the first todo even without a limit, got %v", got)
+	}
+}
+
+func TestPutIsAtomicUnderConcurrency(t *testing.T) {
+	s := open(t)
+
+	const n = 20
+	var wg sync.WaitGroup
+	errs := make([]error, n)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.Put("demo", todo.Todo{Title: "concurrent"})
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	todos, err := s.List("demo", todo.Filter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(todos) != n {
+		t.Fatalf("expected %d concurrent Puts to each create a distinct todo, got %d", n, len(todos))
+	}
+
+	seen := map[int64]bool{}
+	for _, td := range todos {
+		if seen[td.Id] {
+			t.Fatalf("expected concurrent Puts to get distinct ids, saw %d twice", td.Id)
+		}
+		seen[td.Id] = true
+	}
+}
@@ -0,0 +1,35 @@
+package todo
+
+import (
+	"github.com/golang/protobuf/proto"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo/todopb"
+)
+
+// ToProto adapts Todo to the protobuf message generated from todopb/todo.proto,
+// letting the protobuf codec marshal a plain Todo.
+func (t Todo) ToProto() proto.Message {
+	return &todopb.Todo{Id: t.Id, Title: t.Title, Done: t.Done}
+}
+
+// FromProto decodes a protobuf-encoded Todo message into t.
+func (t *Todo) FromProto(data []byte) error {
+	var pb todopb.Todo
+	if err := proto.Unmarshal(data, &pb); err != nil {
+		return err
+	}
+	t.Id, t.Title, t.Done = pb.Id, pb.Title, pb.Done
+	return nil
+}
+
+// TodoList adapts a slice of Todo to the protobuf codec, mirroring
+// todopb.TodoList.
+type TodoList []Todo
+
+func (l TodoList) ToProto() proto.Message {
+	pb := &todopb.TodoList{Todos: make([]*todopb.Todo, len(l))}
+	for i, t := range l {
+		pb.Todos[i] = &todopb.Todo{Id: t.Id, Title: t.Title, Done: t.Done}
+	}
+	return pb
+}
@@ -0,0 +1,47 @@
+package todo
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned by Store implementations when a todo with the
+// requested id does not exist.
+var ErrNotFound = errors.New("todo: not found")
+
+// Filter narrows down the results returned by Store.List.
+type Filter struct {
+	Done   *bool
+	Limit  int
+	Offset int
+}
+
+// EventType identifies the kind of change an Event carries.
+type EventType string
+
+const (
+	EventPut    EventType = "put"
+	EventDelete EventType = "delete"
+)
+
+// Event is emitted on the channel returned by Store.Watch whenever a todo
+// is created, updated or deleted.
+type Event struct {
+	Type EventType
+	Todo Todo
+}
+
+// Store is the persistence boundary for todos. Every method is scoped to a
+// userID so that users only ever see their own todos. Implementations must
+// be safe for concurrent use.
+type Store interface {
+	Get(userID string, id int64) (Todo, error)
+	// List returns userID's todos matching filter, ordered by id.
+	List(userID string, filter Filter) ([]Todo, error)
+	// Put creates todo when Id is zero (assigning an id) or replaces the
+	// existing entry otherwise, returning the stored value.
+	Put(userID string, todo Todo) (Todo, error)
+	Delete(userID string, id int64) error
+	// Watch streams userID's Put/Delete events until ctx is cancelled.
+	Watch(ctx context.Context, userID string) (<-chan Event, error)
+}
@@ -1,13 +1,14 @@
 package todo
 
 import (
-	"encoding/json"
-	"fmt"
+	"errors"
 	"io/ioutil"
 	"net/http"
-	"regexp"
 	"strconv"
-	"sync"
+
+	"github.com/alesbrelih/rest-go-no-framework/auth"
+	"github.com/alesbrelih/rest-go-no-framework/codec"
+	"github.com/alesbrelih/rest-go-no-framework/router"
 )
 
 type Todo struct {
@@ -17,39 +18,16 @@ type Todo struct {
 }
 
 type todoHandlers struct {
-	mu        sync.Mutex // good practice to keep mutex near the data its trying to protect
-	store     map[int64]Todo
-	pathRegex *regexp.Regexp
-}
-
-func (h *todoHandlers) Todos(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case "GET":
-		h.get(w, r)
-		break
-	case "POST":
-		h.post(w, r)
-		break
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("method not allowed"))
-		return
-	}
+	store Store
 }
 
-func (h *todoHandlers) Todo(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case "GET":
-		h.getOne(w, r)
-		break
-	case "DELETE":
-		h.delete(w, r)
-		break
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("method not allowed"))
-		return
-	}
+// Register wires the todo routes onto ro. Callers are expected to gate ro
+// behind auth.Middleware so that auth.UserID has a value to return.
+func (h *todoHandlers) Register(ro router.Registrar) {
+	ro.GET("/todo", h.get)
+	ro.POST("/todo", h.post)
+	ro.GET("/todo/:id", h.getOne)
+	ro.DELETE("/todo/:id", h.delete)
 }
 
 func (h *todoHandlers) post(w http.ResponseWriter, r *http.Request) {
@@ -62,130 +40,137 @@ func (h *todoHandlers) post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ct := r.Header.Get("content-type")
-	if ct != "application/json" {
+	var todo Todo
+	err = codec.ForContentType(r.Header.Get("content-type")).Unmarshal(bodyBytes, &todo)
+	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("application/json required"))
+		w.Write([]byte(err.Error()))
 		return
 	}
 
-	var todo Todo
-	err = json.Unmarshal(bodyBytes, &todo)
+	todo.Id = 0 // ids are assigned by the store
+
+	stored, err := h.store.Put(auth.UserID(r), todo)
 	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
+		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
 		return
 	}
 
-	h.mu.Lock()
-	defer h.mu.Unlock() // i like this
-
-	h.store[todo.Id] = todo
+	if err := codec.WriteResponse(w, r, stored); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
 }
 
 func (h *todoHandlers) get(w http.ResponseWriter, r *http.Request) {
-	todos := make([]Todo, len(h.store))
-
-	h.mu.Lock()
-	i := 0
-	for _, todo := range h.store {
-		todos[i] = todo
-		i++
+	filter, err := parseFilter(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
 	}
-	h.mu.Unlock()
 
-	jsonBytes, err := json.Marshal(todos)
+	todos, err := h.store.List(auth.UserID(r), filter)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte(err.Error()))
 		return
 	}
 
-	w.Header().Add("content-type", "application/json")
-	w.Write(jsonBytes)
+	if err := codec.WriteResponse(w, r, TodoList(todos)); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+	}
 }
 
-func (h *todoHandlers) getOne(w http.ResponseWriter, r *http.Request) {
+// parseFilter reads the optional done/limit/offset query parameters into a
+// Filter, defaulting limit to 50 when not given.
+func parseFilter(r *http.Request) (Filter, error) {
+	filter := Filter{Limit: 50}
 
-	if !h.pathRegex.MatchString(r.URL.Path) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Invalid path, should be /todo/{id}"))
-		return
+	q := r.URL.Query()
+
+	if doneParam := q.Get("done"); doneParam != "" {
+		done, err := strconv.ParseBool(doneParam)
+		if err != nil {
+			return Filter{}, errors.New("done must be a bool")
+		}
+		filter.Done = &done
 	}
 
-	idParam := h.pathRegex.FindStringSubmatch(r.URL.Path)[1]
+	if limitParam := q.Get("limit"); limitParam != "" {
+		limit, err := strconv.Atoi(limitParam)
+		if err != nil {
+			return Filter{}, errors.New("limit must be an int")
+		}
+		filter.Limit = limit
+	}
+
+	if offsetParam := q.Get("offset"); offsetParam != "" {
+		offset, err := strconv.Atoi(offsetParam)
+		if err != nil {
+			return Filter{}, errors.New("offset must be an int")
+		}
+		filter.Offset = offset
+	}
+
+	return filter, nil
+}
+
+func (h *todoHandlers) getOne(w http.ResponseWriter, r *http.Request) {
+	idParam := router.Param(r, "id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Bad stuff happened"))
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid path, should be /todo/{id}"))
 		return
 	}
 
-	h.mu.Lock()
-	todo, found := h.store[id]
-	if !found {
+	todo, err := h.store.Get(auth.UserID(r), id)
+	if errors.Is(err, ErrNotFound) {
 		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte(fmt.Sprintf("Todo with %v does not exist", id)))
+		w.Write([]byte(err.Error()))
 		return
 	}
-	h.mu.Unlock()
-
-	jsonBytes, err := json.Marshal(todo)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		w.Write([]byte("Bad stuff happened"))
 		return
 	}
 
-	w.Header().Add("content-type", "application/json")
-	w.Write(jsonBytes)
+	if err := codec.WriteResponse(w, r, todo); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Bad stuff happened"))
+	}
 }
 
 func (h *todoHandlers) delete(w http.ResponseWriter, r *http.Request) {
-
-	if !h.pathRegex.MatchString(r.URL.Path) {
+	idParam := router.Param(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		w.Write([]byte("Invalid path, should be /todo/{id}"))
 		return
 	}
 
-	idParam := h.pathRegex.FindStringSubmatch(r.URL.Path)[1]
-	id, err := strconv.ParseInt(idParam, 10, 64)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Bad stuff happened"))
+	err = h.store.Delete(auth.UserID(r), id)
+	if errors.Is(err, ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(err.Error()))
 		return
 	}
-
-	h.mu.Lock()
-	_, found := h.store[id]
-	if !found {
-		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte(fmt.Sprintf("Todo with %v does not exist", id)))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
 		return
 	}
-	h.mu.Unlock()
 
-	delete(h.store, id)
-	w.Header().Add("content-type", "application/json")
 	w.WriteHeader(http.StatusOK)
 }
 
-func NewTodoHandlers(pathRegex *regexp.Regexp) *todoHandlers {
-	// using pointer since it will be data storage
-	return &todoHandlers{
-		pathRegex: pathRegex,
-		store: map[int64]Todo{
-			1: {
-				Id:    1,
-				Title: "Do dishes",
-				Done:  false,
-			},
-			2: {
-				Id:    2,
-				Title: "Sweep",
-				Done:  true,
-			},
-		},
-	}
+// NewTodoHandlers wires handlers against store. Seed data, if any, is the
+// responsibility of the chosen Store implementation.
+func NewTodoHandlers(store Store) *todoHandlers {
+	return &todoHandlers{store: store}
 }
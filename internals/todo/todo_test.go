@@ -0,0 +1,257 @@
+package todo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alesbrelih/rest-go-no-framework/auth"
+	"github.com/alesbrelih/rest-go-no-framework/router"
+)
+
+// fakeStore is a minimal, configurable Store used to exercise error paths
+// that the real backends don't make easy to trigger on demand (importing
+// any real Store here would cycle back into this package).
+type fakeStore struct {
+	todos  map[string][]Todo
+	nextID int64
+
+	getErr    error
+	listErr   error
+	putErr    error
+	deleteErr error
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{todos: map[string][]Todo{}}
+}
+
+func (s *fakeStore) Get(userID string, id int64) (Todo, error) {
+	if s.getErr != nil {
+		return Todo{}, s.getErr
+	}
+	for _, t := range s.todos[userID] {
+		if t.Id == id {
+			return t, nil
+		}
+	}
+	return Todo{}, ErrNotFound
+}
+
+func (s *fakeStore) List(userID string, filter Filter) ([]Todo, error) {
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	return s.todos[userID], nil
+}
+
+func (s *fakeStore) Put(userID string, t Todo) (Todo, error) {
+	if s.putErr != nil {
+		return Todo{}, s.putErr
+	}
+	if t.Id == 0 {
+		s.nextID++
+		t.Id = s.nextID
+	}
+	s.todos[userID] = append(s.todos[userID], t)
+	return t, nil
+}
+
+func (s *fakeStore) Delete(userID string, id int64) error {
+	return s.deleteErr
+}
+
+func (s *fakeStore) Watch(ctx context.Context, userID string) (<-chan Event, error) {
+	return nil, errors.New("fakeStore: watch not implemented")
+}
+
+// newTestRouter wires a todoHandlers-backed router gated by auth.Middleware,
+// the same way main wires it, and returns it alongside a valid bearer token
+// for "demo".
+func newTestRouter(t *testing.T, store Store) (*router.Router, string) {
+	t.Helper()
+	secret := []byte("test-secret")
+
+	token, err := auth.IssueToken(secret, "demo", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ro := router.New()
+	protected := ro.Group("")
+	protected.Use(auth.Middleware(secret))
+	NewTodoHandlers(store).Register(protected)
+
+	return ro, token
+}
+
+func TestPostIgnoresClientSuppliedID(t *testing.T) {
+	store := newFakeStore()
+	ro, token := newTestRouter(t, store)
+
+	body, _ := json.Marshal(Todo{Id: 999, Title: "Read a book"})
+	req := httptest.NewRequest(http.MethodPost, "/todo", bytes.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var stored Todo
+	if err := json.Unmarshal(rec.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored.Id == 999 {
+		t.Fatalf("expected the client-supplied id to be ignored, got %d", stored.Id)
+	}
+}
+
+func TestGetFilterParsingErrors(t *testing.T) {
+	store := newFakeStore()
+	ro, token := newTestRouter(t, store)
+
+	for _, query := range []string{"?done=maybe", "?limit=abc", "?offset=abc"} {
+		req := httptest.NewRequest(http.MethodGet, "/todo"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		ro.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("query %q: expected 400, got %d", query, rec.Code)
+		}
+	}
+}
+
+func TestGetListsStoredTodos(t *testing.T) {
+	store := newFakeStore()
+	if _, err := store.Put("demo", Todo{Title: "Do dishes"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var got []Todo
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].Title != "Do dishes" {
+		t.Fatalf("expected the stored todo to come back, got %v", got)
+	}
+}
+
+func TestGetListStoreError(t *testing.T) {
+	store := newFakeStore()
+	store.listErr = errors.New("boom")
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestGetOneNotFound(t *testing.T) {
+	store := newFakeStore()
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo/999", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestGetOneStoreError(t *testing.T) {
+	store := newFakeStore()
+	store.getErr = errors.New("boom")
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestGetOneInvalidID(t *testing.T) {
+	store := newFakeStore()
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodGet, "/todo/not-a-number", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	store := newFakeStore()
+	store.deleteErr = ErrNotFound
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodDelete, "/todo/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestDeleteStoreError(t *testing.T) {
+	store := newFakeStore()
+	store.deleteErr = errors.New("boom")
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodDelete, "/todo/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rec.Code)
+	}
+}
+
+func TestDeleteSuccess(t *testing.T) {
+	store := newFakeStore()
+	ro, token := newTestRouter(t, store)
+
+	req := httptest.NewRequest(http.MethodDelete, "/todo/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+}
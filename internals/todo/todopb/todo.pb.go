@@ -0,0 +1,27 @@
+// Hand-written to match internals/todo/todopb/todo.proto; it is NOT
+// protoc-gen-go output (no protoc toolchain available in this environment),
+// so keep the two in sync by hand when the message shapes change.
+
+package todopb
+
+import (
+	"github.com/golang/protobuf/proto"
+)
+
+type Todo struct {
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Done  bool   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (m *Todo) Reset()         { *m = Todo{} }
+func (m *Todo) String() string { return proto.CompactTextString(m) }
+func (*Todo) ProtoMessage()    {}
+
+type TodoList struct {
+	Todos []*Todo `protobuf:"bytes,1,rep,name=todos,proto3" json:"todos,omitempty"`
+}
+
+func (m *TodoList) Reset()         { *m = TodoList{} }
+func (m *TodoList) String() string { return proto.CompactTextString(m) }
+func (*TodoList) ProtoMessage()    {}
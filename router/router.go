@@ -0,0 +1,212 @@
+// Package router implements a small trie-based HTTP router with support for
+// path parameters, middleware chains and route groups. It has no external
+// dependencies.
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Middleware wraps a handler to add cross-cutting behaviour (logging,
+// recovery, CORS, auth, ...). Middlewares are applied in the order they are
+// registered via Use, outermost first.
+type Middleware func(http.Handler) http.Handler
+
+type paramsKey struct{}
+
+// Param returns the value of the named path parameter extracted while
+// matching the current request, or "" if it isn't set.
+func Param(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
+
+// node is a single segment of the registration trie. A segment is either a
+// literal ("todo") or a parameter (":id"); parameters get their own slot so
+// a literal sibling can't collide with them.
+type node struct {
+	segment    string
+	children   []*node
+	paramChild *node
+	paramName  string
+	handlers   map[string]http.Handler
+}
+
+func (n *node) childFor(segment string) *node {
+	for _, c := range n.children {
+		if c.segment == segment {
+			return c
+		}
+	}
+	return nil
+}
+
+// Router is a method+pattern aware HTTP request multiplexer.
+type Router struct {
+	root        *node
+	middlewares []Middleware
+
+	// NotFound is used when no registered route matches the path.
+	NotFound http.Handler
+	// MethodNotAllowed is used when the path matches a route but not for
+	// the given method.
+	MethodNotAllowed http.Handler
+}
+
+// New creates an empty Router with the default NotFound/MethodNotAllowed
+// handlers.
+func New() *Router {
+	return &Router{
+		root: &node{handlers: map[string]http.Handler{}},
+		NotFound: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "not found", http.StatusNotFound)
+		}),
+		MethodNotAllowed: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}),
+	}
+}
+
+// Use registers middleware applied to every request handled by this router,
+// in the order given.
+func (ro *Router) Use(mw ...Middleware) {
+	ro.middlewares = append(ro.middlewares, mw...)
+}
+
+// Group returns a Group rooted at prefix. Routes registered on the group are
+// registered on the parent router with prefix prepended.
+func (ro *Router) Group(prefix string) *Group {
+	return &Group{router: ro, prefix: strings.TrimSuffix(prefix, "/")}
+}
+
+// Group is a sub-router sharing the parent Router's trie. Middleware added
+// via Use wraps only routes registered on the group, not the whole Router.
+type Group struct {
+	router      *Router
+	prefix      string
+	middlewares []Middleware
+}
+
+// Use registers middleware applied to every route registered on this group
+// from this point on, in the order given.
+func (g *Group) Use(mw ...Middleware) {
+	g.middlewares = append(g.middlewares, mw...)
+}
+
+func (g *Group) Handle(method, pattern string, handler http.Handler) {
+	for i := len(g.middlewares) - 1; i >= 0; i-- {
+		handler = g.middlewares[i](handler)
+	}
+	g.router.Handle(method, g.prefix+pattern, handler)
+}
+
+func (g *Group) GET(pattern string, handler http.HandlerFunc)    { g.Handle(http.MethodGet, pattern, handler) }
+func (g *Group) POST(pattern string, handler http.HandlerFunc)   { g.Handle(http.MethodPost, pattern, handler) }
+func (g *Group) DELETE(pattern string, handler http.HandlerFunc) { g.Handle(http.MethodDelete, pattern, handler) }
+func (g *Group) PUT(pattern string, handler http.HandlerFunc)    { g.Handle(http.MethodPut, pattern, handler) }
+
+// Registrar is satisfied by both Router and Group, so handlers packages
+// (e.g. internals/todo) can register routes without depending on which one
+// they were given.
+type Registrar interface {
+	GET(pattern string, handler http.HandlerFunc)
+	POST(pattern string, handler http.HandlerFunc)
+	DELETE(pattern string, handler http.HandlerFunc)
+	PUT(pattern string, handler http.HandlerFunc)
+}
+
+func splitPath(pattern string) []string {
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+// Handle registers handler for method+pattern. pattern segments prefixed
+// with ":" are treated as path parameters, e.g. "/todo/:id".
+func (ro *Router) Handle(method, pattern string, handler http.Handler) {
+	segments := splitPath(pattern)
+	cur := ro.root
+	for _, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			if cur.paramChild == nil {
+				cur.paramChild = &node{handlers: map[string]http.Handler{}}
+			}
+			cur.paramChild.paramName = strings.TrimPrefix(seg, ":")
+			cur = cur.paramChild
+			continue
+		}
+
+		child := cur.childFor(seg)
+		if child == nil {
+			child = &node{segment: seg, handlers: map[string]http.Handler{}}
+			cur.children = append(cur.children, child)
+		}
+		cur = child
+	}
+	cur.handlers[method] = handler
+}
+
+func (ro *Router) GET(pattern string, handler http.HandlerFunc)    { ro.Handle(http.MethodGet, pattern, handler) }
+func (ro *Router) POST(pattern string, handler http.HandlerFunc)   { ro.Handle(http.MethodPost, pattern, handler) }
+func (ro *Router) DELETE(pattern string, handler http.HandlerFunc) { ro.Handle(http.MethodDelete, pattern, handler) }
+func (ro *Router) PUT(pattern string, handler http.HandlerFunc)    { ro.Handle(http.MethodPut, pattern, handler) }
+
+// match walks the trie for the request path, collecting path parameters
+// along the way. It returns the matched node, or nil if the path matched
+// nothing (independent of whether the method has a handler there), and the
+// collected parameters.
+func (ro *Router) match(path string) (*node, map[string]string) {
+	segments := splitPath(path)
+	cur := ro.root
+	var params map[string]string
+	for _, seg := range segments {
+		if child := cur.childFor(seg); child != nil {
+			cur = child
+			continue
+		}
+		if cur.paramChild == nil {
+			return nil, nil
+		}
+		if params == nil {
+			params = map[string]string{}
+		}
+		params[cur.paramChild.paramName] = seg
+		cur = cur.paramChild
+	}
+	return cur, params
+}
+
+func (ro *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var handler http.Handler
+
+	n, params := ro.match(r.URL.Path)
+	switch {
+	case n == nil || len(n.handlers) == 0:
+		// A node with no handlers was only ever created to reach a
+		// deeper registered route (e.g. "/a/b" on the way to
+		// "/a/b/c") and was never registered for any method itself,
+		// so it's a 404, not a 405.
+		handler = ro.NotFound
+	default:
+		h, ok := n.handlers[r.Method]
+		if !ok {
+			handler = ro.MethodNotAllowed
+		} else {
+			if len(params) > 0 {
+				ctx := context.WithValue(r.Context(), paramsKey{}, params)
+				r = r.WithContext(ctx)
+			}
+			handler = h
+		}
+	}
+
+	for i := len(ro.middlewares) - 1; i >= 0; i-- {
+		handler = ro.middlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
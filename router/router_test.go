@@ -0,0 +1,154 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newRecorded(t *testing.T, ro *Router, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	rec := httptest.NewRecorder()
+	ro.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestParamParsing(t *testing.T) {
+	ro := New()
+	var got string
+	ro.GET("/todo/:id", func(w http.ResponseWriter, r *http.Request) {
+		got = Param(r, "id")
+	})
+
+	newRecorded(t, ro, http.MethodGet, "/todo/42")
+
+	if got != "42" {
+		t.Fatalf("expected param id to be 42, got %q", got)
+	}
+}
+
+func TestTrailingSlash(t *testing.T) {
+	ro := New()
+	called := false
+	ro.GET("/todo", func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	rec := newRecorded(t, ro, http.MethodGet, "/todo/")
+
+	if !called || rec.Code != http.StatusOK {
+		t.Fatalf("expected trailing slash to match same route, called=%v code=%d", called, rec.Code)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	ro := New()
+	ro.GET("/todo", func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := newRecorded(t, ro, http.MethodGet, "/unknown")
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestIntermediateSegmentIsNotFound(t *testing.T) {
+	ro := New()
+	ro.GET("/a/b/c", func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := newRecorded(t, ro, http.MethodGet, "/a/b")
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unregistered intermediate segment, got %d", rec.Code)
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	ro := New()
+	ro.GET("/todo", func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := newRecorded(t, ro, http.MethodPost, "/todo")
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+}
+
+func TestConflictingRoutesPreferLiteralOverParam(t *testing.T) {
+	ro := New()
+	var matched string
+	ro.GET("/todo/active", func(w http.ResponseWriter, r *http.Request) {
+		matched = "literal"
+	})
+	ro.GET("/todo/:id", func(w http.ResponseWriter, r *http.Request) {
+		matched = "param"
+	})
+
+	newRecorded(t, ro, http.MethodGet, "/todo/active")
+	if matched != "literal" {
+		t.Fatalf("expected literal segment to win over param, got %q", matched)
+	}
+
+	newRecorded(t, ro, http.MethodGet, "/todo/7")
+	if matched != "param" {
+		t.Fatalf("expected param segment to match unknown literal, got %q", matched)
+	}
+}
+
+func TestMiddlewareOrder(t *testing.T) {
+	ro := New()
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	ro.Use(mw("outer"), mw("inner"))
+	ro.GET("/todo", func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	})
+
+	newRecorded(t, ro, http.MethodGet, "/todo")
+
+	want := []string{"outer", "inner", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, order)
+		}
+	}
+}
+
+func TestGroupMiddlewareIsScoped(t *testing.T) {
+	ro := New()
+	protectedCalled, publicCalled := false, false
+
+	protected := ro.Group("/admin")
+	protected.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+	})
+	protected.GET("/secret", func(w http.ResponseWriter, r *http.Request) {
+		protectedCalled = true
+	})
+	ro.GET("/public", func(w http.ResponseWriter, r *http.Request) {
+		publicCalled = true
+	})
+
+	rec := newRecorded(t, ro, http.MethodGet, "/admin/secret")
+	if rec.Code != http.StatusForbidden || protectedCalled {
+		t.Fatalf("expected group middleware to block the protected route, code=%d called=%v", rec.Code, protectedCalled)
+	}
+
+	rec = newRecorded(t, ro, http.MethodGet, "/public")
+	if rec.Code != http.StatusOK || !publicCalled {
+		t.Fatalf("expected public route to be unaffected by group middleware, code=%d called=%v", rec.Code, publicCalled)
+	}
+}
@@ -1,215 +1,86 @@
 package main
 
 import (
-	"encoding/json"
-	"fmt"
-	"io/ioutil"
+	"context"
 	"net/http"
-	"regexp"
-	"strconv"
-	"sync"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alesbrelih/rest-go-no-framework/auth"
+	"github.com/alesbrelih/rest-go-no-framework/internals/store/memory"
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+	"github.com/alesbrelih/rest-go-no-framework/router"
 )
 
-var pathRegex *regexp.Regexp
+const (
+	tokenTTL = time.Hour
 
-type Todo struct {
-	Id    int64  `json:"id"`
-	Title string `json:"title"`
-	Done  bool   `json:"done"`
-}
-
-type todoHandlers struct {
-	mu    sync.Mutex // good practice to keep mutex near the data its trying to protect
-	store map[int64]Todo
-}
-
-func (h *todoHandlers) todos(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case "GET":
-		h.get(w, r)
-		break
-	case "POST":
-		h.post(w, r)
-		break
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("method not allowed"))
-		return
-	}
-}
-
-func (h *todoHandlers) todo(w http.ResponseWriter, r *http.Request) {
-	switch r.Method {
-	case "GET":
-		h.getOne(w, r)
-		break
-	case "DELETE":
-		h.delete(w, r)
-		break
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		w.Write([]byte("method not allowed"))
-		return
-	}
-}
-
-func (h *todoHandlers) post(w http.ResponseWriter, r *http.Request) {
-	bodyBytes, err := ioutil.ReadAll(r.Body)
-	defer r.Body.Close()
-
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(err.Error()))
-		return
-	}
-
-	ct := r.Header.Get("content-type")
-	if ct != "application/json" {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("application/json required"))
-		return
-	}
-
-	var todo Todo
-	err = json.Unmarshal(bodyBytes, &todo)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte(err.Error()))
-		return
-	}
-
-	h.mu.Lock()
-	defer h.mu.Unlock() // i like this
-
-	h.store[todo.Id] = todo
-}
-
-func (h *todoHandlers) get(w http.ResponseWriter, r *http.Request) {
-	todos := make([]Todo, len(h.store))
-
-	h.mu.Lock()
-	i := 0
-	for _, todo := range h.store {
-		todos[i] = todo
-		i++
-	}
-	h.mu.Unlock()
-
-	jsonBytes, err := json.Marshal(todos)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte(err.Error()))
-		return
-	}
-
-	w.Header().Add("content-type", "application/json")
-	w.Write(jsonBytes)
-}
+	readHeaderTimeout = 5 * time.Second
+	writeTimeout      = 10 * time.Second
+	idleTimeout       = 120 * time.Second
+	shutdownGrace     = 10 * time.Second
+)
 
-func (h *todoHandlers) getOne(w http.ResponseWriter, r *http.Request) {
+// What does this means, anyway?
 
-	if !pathRegex.MatchString(r.URL.Path) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Invalid path, should be /todo/{id}"))
-		return
-	}
+// In simple terms, value receiver makes a copy of the type and pass it to the function. The function stack now holds an equal object but at a different location on memory.
 
-	idParam := pathRegex.FindStringSubmatch(r.URL.Path)[1]
-	id, err := strconv.ParseInt(idParam, 10, 64)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Bad stuff happened"))
-		return
-	}
+// Pointer receiver passes the address of a type to the function. The function stack has a reference to the original object.
+func main() {
+	secret := jwtSecret()
+	users := auth.NewMemoryUsers()
+	pow := auth.NewHashcashChallenger(20, 10000)
 
-	h.mu.Lock()
-	todo, found := h.store[id]
-	if !found {
-		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte(fmt.Sprintf("Todo with %v does not exist", id)))
-		return
-	}
-	h.mu.Unlock()
+	ro := router.New()
 
-	jsonBytes, err := json.Marshal(todo)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Bad stuff happened"))
-		return
-	}
+	public := ro.Group("")
+	public.POST("/login", auth.LoginHandler(secret, users, tokenTTL))
 
-	w.Header().Add("content-type", "application/json")
-	w.Write(jsonBytes)
-}
+	signup := ro.Group("")
+	signup.Use(pow.RequireProofOfWork)
+	signup.POST("/signup", auth.SignupHandler(secret, users, tokenTTL))
 
-func (h *todoHandlers) delete(w http.ResponseWriter, r *http.Request) {
+	store := memory.New()
 
-	if !pathRegex.MatchString(r.URL.Path) {
-		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("Invalid path, should be /todo/{id}"))
-		return
-	}
+	protected := ro.Group("")
+	protected.Use(auth.Middleware(secret))
+	todoHandlers := todo.NewTodoHandlers(store)
+	todoHandlers.Register(protected)
+	protected.GET("/todo/stream", todoStreamHandler(store))
 
-	idParam := pathRegex.FindStringSubmatch(r.URL.Path)[1]
-	id, err := strconv.ParseInt(idParam, 10, 64)
-	if err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
-		w.Write([]byte("Bad stuff happened"))
-		return
+	srv := &http.Server{
+		Addr:              ":8080",
+		Handler:           ro,
+		ReadHeaderTimeout: readHeaderTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
 	}
 
-	h.mu.Lock()
-	_, found := h.store[id]
-	if !found {
-		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte(fmt.Sprintf("Todo with %v does not exist", id)))
-		return
-	}
-	h.mu.Unlock()
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			panic(err)
+		}
+	}()
 
-	delete(h.store, id)
-	w.Header().Add("content-type", "application/json")
-	w.WriteHeader(http.StatusOK)
-}
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
 
-func newTodoHandlers() *todoHandlers {
-	// using pointer since it will be data storage
-	return &todoHandlers{
-		store: map[int64]Todo{
-			1: {
-				Id:    1,
-				Title: "Do dishes",
-				Done:  false,
-			},
-			2: {
-				Id:    2,
-				Title: "Sweep",
-				Done:  true,
-			},
-		},
-	}
-}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+	defer cancel()
 
-func init() {
-	var err error
-	pathRegex, err = regexp.Compile("^/?todo/([\\d]+)/?$")
-	if err != nil {
-		panic(err.Error())
+	if err := srv.Shutdown(ctx); err != nil {
+		panic(err)
 	}
 }
 
-// What does this means, anyway?
-
-// In simple terms, value receiver makes a copy of the type and pass it to the function. The function stack now holds an equal object but at a different location on memory.
-
-// Pointer receiver passes the address of a type to the function. The function stack has a reference to the original object.
-func main() {
-	todoHandlers := newTodoHandlers()
-	http.HandleFunc("/todo", todoHandlers.todos)
-	http.HandleFunc("/todo/", todoHandlers.todo)
-
-	err := http.ListenAndServe(":8080", nil)
-	if err != nil {
-		panic(err)
+// jwtSecret reads the signing secret from JWT_SECRET, falling back to a
+// fixed development value so the server still runs out of the box.
+func jwtSecret() []byte {
+	if secret := os.Getenv("JWT_SECRET"); secret != "" {
+		return []byte(secret)
 	}
+	return []byte("dev-secret-do-not-use-in-production")
 }
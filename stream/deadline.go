@@ -0,0 +1,56 @@
+// Package stream provides deadline-aware helpers for long-running read
+// operations (watches, SSE), modeled on the deadlineTimer pattern used by
+// gVisor's netstack gonet adapter: a small struct holding a cancellable
+// channel and the *time.Timer driving it, so callers can select on a
+// channel instead of blocking forever.
+package stream
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer arms a channel that closes once a configured deadline
+// elapses, so it can be used in a select alongside other channels.
+type deadlineTimer struct {
+	mu      sync.Mutex
+	timer   *time.Timer
+	expired chan struct{}
+}
+
+func newDeadlineTimer() *deadlineTimer {
+	return &deadlineTimer{expired: make(chan struct{})}
+}
+
+// set arms the deadline at t. A zero t disarms it. Calling set again before
+// expiry replaces the previous deadline.
+func (d *deadlineTimer) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+
+	select {
+	case <-d.expired:
+		d.expired = make(chan struct{})
+	default:
+	}
+
+	if t.IsZero() {
+		d.timer = nil
+		return
+	}
+
+	expired := d.expired
+	d.timer = time.AfterFunc(time.Until(t), func() { close(expired) })
+}
+
+// channel returns the channel closed once the deadline elapses. It is never
+// closed if no deadline has been set.
+func (d *deadlineTimer) channel() <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.expired
+}
@@ -0,0 +1,83 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+// ErrDeadlineExceeded is returned by Next when the read deadline elapses
+// before an event arrives.
+var ErrDeadlineExceeded = errors.New("stream: deadline exceeded")
+
+// TodoWatcher streams a single user's todo.Events with independent read and
+// write deadlines, so a slow client can be dropped without blocking server
+// shutdown. It backs the GET /todo/stream SSE endpoint.
+type TodoWatcher struct {
+	events <-chan todo.Event
+	cancel context.CancelFunc
+
+	readDeadline  *deadlineTimer
+	writeDeadline *deadlineTimer
+}
+
+// NewTodoWatcher starts watching userID's todos on store until the returned
+// TodoWatcher is closed or ctx is cancelled.
+func NewTodoWatcher(ctx context.Context, store todo.Store, userID string) (*TodoWatcher, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	events, err := store.Watch(ctx, userID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	return &TodoWatcher{
+		events:        events,
+		cancel:        cancel,
+		readDeadline:  newDeadlineTimer(),
+		writeDeadline: newDeadlineTimer(),
+	}, nil
+}
+
+// SetReadDeadline arms the deadline past which Next gives up waiting for an
+// event. A zero Time disarms it.
+func (w *TodoWatcher) SetReadDeadline(t time.Time) {
+	w.readDeadline.set(t)
+}
+
+// SetWriteDeadline arms the deadline past which a caller flushing an event
+// to a slow client should give up, consulted via WriteDeadline.
+func (w *TodoWatcher) SetWriteDeadline(t time.Time) {
+	w.writeDeadline.set(t)
+}
+
+// WriteDeadline returns the channel closed once the write deadline elapses,
+// for callers to select on alongside an outbound write.
+func (w *TodoWatcher) WriteDeadline() <-chan struct{} {
+	return w.writeDeadline.channel()
+}
+
+// Next blocks for the next event, honoring ctx, the read deadline, and the
+// watcher being closed, whichever comes first.
+func (w *TodoWatcher) Next(ctx context.Context) (todo.Event, error) {
+	select {
+	case evt, ok := <-w.events:
+		if !ok {
+			return todo.Event{}, io.EOF
+		}
+		return evt, nil
+	case <-w.readDeadline.channel():
+		return todo.Event{}, ErrDeadlineExceeded
+	case <-ctx.Done():
+		return todo.Event{}, ctx.Err()
+	}
+}
+
+// Close stops the underlying watch.
+func (w *TodoWatcher) Close() {
+	w.cancel()
+}
@@ -0,0 +1,65 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alesbrelih/rest-go-no-framework/internals/store/memory"
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+)
+
+func TestTodoWatcherReceivesEvents(t *testing.T) {
+	store := memory.New()
+	w, err := NewTodoWatcher(context.Background(), store, "demo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	if _, err := store.Put("demo", todo.Todo{Title: "Water the plants"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	evt, err := w.Next(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evt.Todo.Title != "Water the plants" {
+		t.Fatalf("unexpected event: %+v", evt)
+	}
+}
+
+func TestTodoWatcherReadDeadline(t *testing.T) {
+	store := memory.New()
+	w, err := NewTodoWatcher(context.Background(), store, "demo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	w.SetReadDeadline(time.Now().Add(10 * time.Millisecond))
+
+	_, err = w.Next(context.Background())
+	if err != ErrDeadlineExceeded {
+		t.Fatalf("expected ErrDeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTodoWatcherClosedContext(t *testing.T) {
+	store := memory.New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w, err := NewTodoWatcher(ctx, store, "demo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer w.Close()
+
+	cancel()
+
+	_, err = w.Next(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error once the watch context is cancelled")
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/alesbrelih/rest-go-no-framework/auth"
+	"github.com/alesbrelih/rest-go-no-framework/internals/todo"
+	"github.com/alesbrelih/rest-go-no-framework/stream"
+)
+
+const streamWriteTimeout = 5 * time.Second
+
+// todoStreamHandler returns a GET /todo/stream SSE handler streaming the
+// caller's todo.Events as they happen. It selects on r.Context().Done() (via
+// TodoWatcher.Next) and on a per-write deadline so a slow or disconnected
+// client can't block server shutdown.
+func todoStreamHandler(store todo.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("streaming not supported"))
+			return
+		}
+
+		watcher, err := stream.NewTodoWatcher(r.Context(), store, auth.UserID(r))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		defer watcher.Close()
+
+		w.Header().Set("content-type", "text/event-stream")
+		w.Header().Set("cache-control", "no-cache")
+		w.Header().Set("connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		for {
+			evt, err := watcher.Next(r.Context())
+			if err != nil {
+				// io.EOF (store closed the watch), ctx cancellation, and
+				// ErrDeadlineExceeded all mean the same thing here: stop.
+				return
+			}
+
+			data, err := json.Marshal(evt)
+			if err != nil {
+				return
+			}
+
+			if !writeEvent(w, flusher, watcher, data) {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent flushes an SSE frame on its own goroutine and selects between
+// it completing and watcher's write deadline elapsing, so a client that
+// stops reading can't hang the handler goroutine forever.
+func writeEvent(w http.ResponseWriter, flusher http.Flusher, watcher *stream.TodoWatcher, data []byte) bool {
+	watcher.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := fmt.Fprintf(w, "data: %s\n\n", data)
+		if err == nil {
+			flusher.Flush()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err == nil
+	case <-watcher.WriteDeadline():
+		return false
+	}
+}